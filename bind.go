@@ -0,0 +1,153 @@
+package csf
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// tagKey is the struct tag key read by Bind and TemplateFromStruct.
+const tagKey = "csf"
+
+// fieldTag holds the parsed directives from a single field's csf struct tag.
+type fieldTag struct {
+	id        string
+	required  bool
+	def       string
+	omitEmpty bool
+}
+
+// parseFieldTag parses a csf struct tag, such as
+// `csf:"id,required,default=foo,omitempty"`. If tag is empty or "-", ok is
+// false and the field should be ignored. If the id segment is empty, name
+// (the Go field name) is used instead.
+func parseFieldTag(name, tag string) (ft fieldTag, ok bool, err error) {
+	if tag == "" || tag == "-" {
+		return fieldTag{}, false, nil
+	}
+	parts := strings.Split(tag, ",")
+	id := parts[0]
+	if id == "" {
+		id = name
+	}
+	ft = fieldTag{id: id}
+	for _, opt := range parts[1:] {
+		switch {
+		case opt == "required":
+			ft.required = true
+		case opt == "omitempty":
+			ft.omitEmpty = true
+		case strings.HasPrefix(opt, "default="):
+			ft.def = strings.TrimPrefix(opt, "default=")
+		default:
+			return fieldTag{}, false, fmt.Errorf("csf: unknown tag option %q in struct tag %q", opt, tag)
+		}
+	}
+	return ft, true, nil
+}
+
+// structType resolves v (a struct or a pointer to one) to its reflect.Type.
+func structType(v any) (reflect.Type, error) {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return nil, fmt.Errorf("csf: expected a struct or struct pointer, got nil")
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("csf: expected a struct or struct pointer, got %s", t)
+	}
+	return t, nil
+}
+
+// structFieldTags walks v's struct type and returns the parsed fieldTag for
+// every exported field carrying a csf tag, in field declaration order.
+func structFieldTags(v any) ([]fieldTag, error) {
+	t, err := structType(v)
+	if err != nil {
+		return nil, err
+	}
+	tags := make([]fieldTag, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" {
+			continue
+		}
+		ft, ok, err := parseFieldTag(sf.Name, sf.Tag.Get(tagKey))
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		tags = append(tags, ft)
+	}
+	return tags, nil
+}
+
+// Bind walks v (a struct or a pointer to one) via reflection and produces a
+// context map suitable for Template.String, driven by `csf:"..."` struct
+// tags. A field's tag id becomes its context map key; "omitempty" omits the
+// key entirely when the field holds its zero value (letting a Field's
+// Default, if any, apply instead). Fields without a csf tag, and unexported
+// fields, are ignored. A field tagged `csf:"-"` is also ignored.
+func Bind(v any) (map[string]any, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, fmt.Errorf("csf: Bind requires a non-nil struct pointer")
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("csf: Bind requires a struct or struct pointer, got %s", rv.Kind())
+	}
+
+	rt := rv.Type()
+	ctx := make(map[string]any, rt.NumField())
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if sf.PkgPath != "" {
+			continue
+		}
+		ft, ok, err := parseFieldTag(sf.Name, sf.Tag.Get(tagKey))
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		fv := rv.Field(i)
+		if ft.omitEmpty && fv.IsZero() {
+			continue
+		}
+		ctx[ft.id] = fv.Interface()
+	}
+	return ctx, nil
+}
+
+// TemplateFromStruct constructs a Template whose Field entries mirror the
+// `csf:"..."` tags on v's struct type (a struct or a pointer to one), so
+// callers don't have to duplicate their schema between a struct definition
+// and F(...) calls. "required" sets Field.Required and "default=..." sets
+// Field.Default; "omitempty" only affects Bind and is not reflected in the
+// resulting Template. Use Bind(v) to produce the matching context map.
+func TemplateFromStruct(v any) (*Template, error) {
+	tags, err := structFieldTags(v)
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]Eval, 0, len(tags))
+	for _, ft := range tags {
+		f := F(ft.id)
+		if ft.required {
+			f.Required()
+		}
+		if ft.def != "" {
+			f.Default(ft.def)
+		}
+		fields = append(fields, f)
+	}
+	return NewTemplate(fields...), nil
+}
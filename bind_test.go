@@ -0,0 +1,90 @@
+package csf
+
+import "testing"
+
+type bindFixture struct {
+	Name    string `csf:"name,required"`
+	Tag     string `csf:"tag,default=latest,omitempty"`
+	Count   int    `csf:"count,omitempty"`
+	ignored string
+	Skipped string `csf:"-"`
+}
+
+func Test_BindBasic(t *testing.T) {
+	ctx, err := Bind(bindFixture{Name: "web", Tag: "v2", Count: 3, Skipped: "x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ctx["name"] != "web" || ctx["tag"] != "v2" || ctx["count"] != 3 {
+		t.Fatalf("unexpected context: %#v", ctx)
+	}
+	if _, ok := ctx["Skipped"]; ok {
+		t.Fatalf("expected csf:\"-\" field to be excluded, got %#v", ctx)
+	}
+	if _, ok := ctx["ignored"]; ok {
+		t.Fatalf("expected unexported field to be excluded, got %#v", ctx)
+	}
+}
+
+func Test_BindOmitEmpty(t *testing.T) {
+	ctx, err := Bind(bindFixture{Name: "web"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ctx["count"]; ok {
+		t.Fatalf("expected zero-value 'count' to be omitted, got %#v", ctx)
+	}
+}
+
+func Test_BindPointer(t *testing.T) {
+	ctx, err := Bind(&bindFixture{Name: "web"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ctx["name"] != "web" {
+		t.Fatalf("unexpected context: %#v", ctx)
+	}
+}
+
+func Test_BindNotStruct(t *testing.T) {
+	if _, err := Bind("nope"); err == nil {
+		t.Fatal("expected error for non-struct value")
+	}
+}
+
+func Test_BindUnknownTagOption(t *testing.T) {
+	type bad struct {
+		A string `csf:"a,bogus"`
+	}
+	if _, err := Bind(bad{A: "x"}); err == nil {
+		t.Fatal("expected error for unknown tag option")
+	}
+}
+
+func Test_TemplateFromStruct(t *testing.T) {
+	tmpl, err := TemplateFromStruct(bindFixture{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, err := Bind(bindFixture{Name: "web"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := tmpl.String(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "web latest" {
+		t.Fatalf("expected 'web latest', got %q", s)
+	}
+}
+
+func Test_TemplateFromStructMissingRequired(t *testing.T) {
+	tmpl, err := TemplateFromStruct(bindFixture{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tmpl.String(map[string]any{}); err == nil {
+		t.Fatal("expected error for missing required field")
+	}
+}
@@ -0,0 +1,88 @@
+package csf
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AllMatch is an Eval-confirming implementation that evaluates a list of
+// fields and returns their space-joined result only if every field resolves
+// to a non-empty value; otherwise it returns an empty string.
+type AllMatch struct {
+	fields []Eval
+}
+
+// String returns the space-joined result of every field in All, but only if
+// all of them evaluate to a non-empty value. If any field is empty (or the
+// field list is empty), an empty string is returned. If an error occurs
+// while evaluating a field, it returns the error directly.
+func (a *AllMatch) String(c map[string]any) (string, error) {
+	parts := make([]string, 0, len(a.fields))
+	for i, f := range a.fields {
+		s, err := f.String(c)
+		if err != nil {
+			return "", fmt.Errorf("error evaluating field %d: %w", i, err)
+		}
+		if s == "" {
+			return "", nil
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, " "), nil
+}
+
+// All creates an evaluator that returns the space-joined result of every
+// field only if all fields resolve to a non-empty value, otherwise returning
+// an empty string. This is useful for values that only make sense together,
+// e.g. omitting a flag entirely unless its value also resolves.
+func All(fields ...Eval) *AllMatch {
+	return &AllMatch{
+		fields: fields,
+	}
+}
+
+// Conditional is an Eval-confirming implementation that evaluates one of two
+// branches depending on a predicate over the context map.
+type Conditional struct {
+	pred func(c map[string]any) bool
+	then Eval
+	els  Eval
+}
+
+// String evaluates the predicate against c and returns the result of the
+// matching branch. Either branch may be nil, in which case that branch
+// resolves to an empty string.
+func (w *Conditional) String(c map[string]any) (string, error) {
+	branch := w.els
+	if w.pred(c) {
+		branch = w.then
+	}
+	if branch == nil {
+		return "", nil
+	}
+	return branch.String(c)
+}
+
+// When creates a conditional evaluator: if pred(ctx) is true, then is
+// evaluated; otherwise els is evaluated. Either branch may be nil, in which
+// case that branch resolves to an empty string. This lets a Template include
+// a value only under conditions that depend on the whole context map, rather
+// than on a single field's presence.
+func When(pred func(c map[string]any) bool, then Eval, els Eval) *Conditional {
+	return &Conditional{
+		pred: pred,
+		then: then,
+		els:  els,
+	}
+}
+
+// Group creates an evaluator equivalent to an inline Template: it evaluates
+// fields and joins their non-empty results using sep (including a genuinely
+// empty sep, for plain concatenation), independent of any enclosing
+// Template's own separator. This is useful for nested groupings that should
+// not inherit the outer separator, e.g. joining a flag and its value with
+// "=" (or "" for direct concatenation) inside a space-separated
+// command-line template.
+func Group(sep string, fields ...Eval) *Template {
+	return NewTemplateWithOptions(TemplateOptions{Separator: sep, NoSeparator: sep == ""}, fields...)
+}
@@ -0,0 +1,108 @@
+package csf
+
+import "testing"
+
+func Test_AllPresent(t *testing.T) {
+	st := NewTemplate(
+		All(F("flag"), F("value")),
+	)
+	s, err := st.String(map[string]any{"flag": "--size", "value": "10"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "--size 10" {
+		t.Fatalf("expected '--size 10', got %q", s)
+	}
+}
+
+func Test_AllMissingOne(t *testing.T) {
+	st := NewTemplate(
+		All(F("flag"), F("value")),
+	)
+	s, err := st.String(map[string]any{"flag": "--size"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "" {
+		t.Fatalf("expected empty string, got %q", s)
+	}
+}
+
+func Test_AllError(t *testing.T) {
+	st := NewTemplate(
+		All(F("flag").Required(), F("value")),
+	)
+	if _, err := st.String(map[string]any{"value": "10"}); err == nil {
+		t.Fatal("expected error for missing required field")
+	}
+}
+
+func Test_WhenThen(t *testing.T) {
+	st := NewTemplate(
+		When(func(c map[string]any) bool {
+			return c["debug"] == true
+		}, C("--verbose"), C("--quiet")),
+	)
+	s, err := st.String(map[string]any{"debug": true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "--verbose" {
+		t.Fatalf("expected '--verbose', got %q", s)
+	}
+}
+
+func Test_WhenElse(t *testing.T) {
+	st := NewTemplate(
+		When(func(c map[string]any) bool {
+			return c["debug"] == true
+		}, C("--verbose"), C("--quiet")),
+	)
+	s, err := st.String(map[string]any{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "--quiet" {
+		t.Fatalf("expected '--quiet', got %q", s)
+	}
+}
+
+func Test_WhenNilBranch(t *testing.T) {
+	st := NewTemplate(
+		When(func(c map[string]any) bool { return false }, C("--verbose"), nil),
+	)
+	s, err := st.String(map[string]any{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "" {
+		t.Fatalf("expected empty string, got %q", s)
+	}
+}
+
+func Test_Group(t *testing.T) {
+	st := NewTemplate(
+		C("run"),
+		Group("=", C("--flag"), F("value")),
+	)
+	s, err := st.String(map[string]any{"value": "10"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "run --flag=10" {
+		t.Fatalf("expected 'run --flag=10', got %q", s)
+	}
+}
+
+func Test_GroupEmptySeparator(t *testing.T) {
+	st := NewTemplate(
+		Group("", C("--"), F("name")),
+	)
+	s, err := st.String(map[string]any{"name": "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "--foo" {
+		t.Fatalf("expected '--foo', got %q", s)
+	}
+}
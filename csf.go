@@ -46,6 +46,14 @@ func Const(s string) Stringer {
 	}
 }
 
+// Named is implemented by Eval values that have a stable identifier, such as
+// the context map key a Field resolves. Template uses it to label fields
+// when rendering structured output formats (see Render).
+type Named interface {
+	// ID returns the field's identifier.
+	ID() string
+}
+
 // Eval is a generic interface for evaluating the value of a field in a given
 // context map as a string value.
 type Eval interface {
@@ -90,20 +98,27 @@ func (f *Field) Default(v any) *Field {
 // Formatter sets a custom Stringer function to format the field's value. This
 // allows for custom formatting logic to be applied to the field's value when
 // generating the string representation. If not set, the default Value function
-// is used to convert the value to a string.
+// is used to convert the value to a string. A *FormatterRegistry may also be
+// used here via its Dispatch method, e.g. F("a").Formatter(reg.Dispatch).
 func (f *Field) Formatter(fmt Stringer) *Field {
 	f.format = fmt
 	return f
 }
 
 // String evaluates the field's value in the context map and returns its
-// string representation using its provided Stringer. A non-nil error is
-// returned if the field is required and not present in the context map (and no
-// acceptable default value is provided). An empty string indicates the field
-// was not found or its value is nil.
+// string representation using its provided Stringer. The field's id is
+// resolved as a PathSep-delimited path (see resolvePath), so e.g.
+// F("service.image.tag") reads ctx["service"].(map[string]any)["image"].
+// (map[string]any)["tag"]. A non-nil error is returned if an intermediate
+// path segment is not a map[string]any or []any, or if the field is required and not
+// present in the context map (and no acceptable default value is provided).
+// An empty string indicates the field was not found or its value is nil.
 func (f *Field) String(c map[string]any) (string, error) {
-	v := c[f.id]
-	if v == nil {
+	v, found, err := resolvePath(c, f.id)
+	if err != nil {
+		return "", err
+	}
+	if !found {
 		v = f.def
 	}
 	if v == nil {
@@ -115,6 +130,11 @@ func (f *Field) String(c map[string]any) (string, error) {
 	return f.format(v), nil
 }
 
+// ID returns the field's context map key, satisfying Named.
+func (f *Field) ID() string {
+	return f.id
+}
+
 // F creates a new Field instance with the provided id and sets the default
 // format to Value. The field instance defaults to being optional (not required)
 // and has no default value.
@@ -130,6 +150,7 @@ func F(id string) *Field {
 // string, if any.
 type FirstMatch struct {
 	fields []Eval
+	id     string
 }
 
 // String returns the first non-zero/non-nil value from the list of fields
@@ -146,6 +167,20 @@ func (f *FirstMatch) String(c map[string]any) (string, error) {
 	return "", nil
 }
 
+// ID returns the identifier assigned via As, satisfying Named. It returns an
+// empty string if As was never called.
+func (f *FirstMatch) ID() string {
+	return f.id
+}
+
+// As assigns an identifier to the FirstMatch, letting it act as a Named slot
+// (e.g. for FormatJSON/FormatKV rendering) despite not resolving to a single
+// fixed context map key.
+func (f *FirstMatch) As(id string) *FirstMatch {
+	f.id = id
+	return f
+}
+
 // First creates an evaluator that returns the first non-nil/non-zero value from
 // a list of field values, otherwise returning an empty string. This is useful
 // for cases where fields have some form of mutually exclusive relationships.
@@ -159,7 +194,8 @@ func First(fields ...Eval) *FirstMatch {
 // provided constant string value. This is useful for cases where a fixed
 // string value is needed in the template without evaluating any context map.
 type Constant struct {
-	v string
+	v  string
+	id string
 }
 
 // String returns the constant value as a string. It does not evaluate the
@@ -168,6 +204,20 @@ func (c *Constant) String(_ map[string]any) (string, error) {
 	return c.v, nil
 }
 
+// ID returns the identifier assigned via As, satisfying Named. It returns an
+// empty string if As was never called.
+func (c *Constant) ID() string {
+	return c.id
+}
+
+// As assigns an identifier to the Constant, letting it act as a Named slot
+// (e.g. for FormatJSON/FormatKV rendering) despite not resolving to a context
+// map key of its own.
+func (c *Constant) As(id string) *Constant {
+	c.id = id
+	return c
+}
+
 // C creates a new Constant instance with the provided string value. This
 // instance can be used in a template to return a fixed string value without
 // evaluating the context map.
@@ -182,13 +232,27 @@ func C(v string) *Constant {
 // conditional inclusion behavior and formatting delegates.
 type Template struct {
 	fields []Eval
+	opts   TemplateOptions
+}
+
+// sep returns the template's configured join separator, defaulting to " "
+// when neither Separator nor NoSeparator was set via NewTemplateWithOptions.
+func (t Template) sep() string {
+	if t.opts.NoSeparator {
+		return ""
+	}
+	if t.opts.Separator != "" {
+		return t.opts.Separator
+	}
+	return " "
 }
 
 // String generates a string representation of the template using the provided
 // context map inputs. Each template Eval is evaluated in order, returning any
 // non-nil errors. Otherwise, the corresponding string, if non-empty, is
-// concatenated into a single string with a space separator. If no fields are
-// found or all are nil, an empty string is returned.
+// concatenated into a single string with the template's separator (a space,
+// unless overridden via NewTemplateWithOptions). If no fields are found or
+// all are nil, an empty string is returned.
 func (t Template) String(ctx map[string]any) (string, error) {
 	sb := make([]string, 0, len(t.fields))
 	for _, f := range t.fields {
@@ -198,12 +262,14 @@ func (t Template) String(ctx map[string]any) (string, error) {
 			sb = append(sb, s)
 		}
 	}
-	return strings.Join(sb, " "), nil
+	return strings.Join(sb, t.sep()), nil
 }
 
 // NewTemplate creates a new Template instance with the provided list of Eval
 // values. The fields are stored in the order they are provided and will be
-// evaluated in that order when generating the string representation.
+// evaluated in that order when generating the string representation. The
+// resulting Template joins rendered fields with a space; use
+// NewTemplateWithOptions to configure the separator or rendering mode.
 func NewTemplate(fields ...Eval) *Template {
 	return &Template{
 		fields: fields,
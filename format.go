@@ -0,0 +1,184 @@
+package csf
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// predicateFormatter pairs a type predicate with the Stringer to use when it
+// matches.
+type predicateFormatter struct {
+	pred func(v any) bool
+	fmt  Stringer
+}
+
+// FormatterRegistry maps Go types (and, via RegisterFunc, arbitrary
+// predicates) to Stringer functions, dispatching on the runtime type of the
+// value being formatted. Pass its Dispatch method directly to
+// Field.Formatter, e.g. F("a").Formatter(reg.Dispatch).
+type FormatterRegistry struct {
+	byType map[reflect.Type]Stringer
+	preds  []predicateFormatter
+	fall   Stringer
+}
+
+// NewFormatterRegistry creates an empty FormatterRegistry. Values with no
+// matching type or predicate entry are formatted using Value, unless
+// overridden via Fallback.
+func NewFormatterRegistry() *FormatterRegistry {
+	return &FormatterRegistry{
+		byType: make(map[reflect.Type]Stringer),
+		fall:   Value,
+	}
+}
+
+// Register associates the exact type of sample (whose value is otherwise
+// unused) with fmt, so any value of that type dispatches to fmt.
+func (r *FormatterRegistry) Register(sample any, fmt Stringer) *FormatterRegistry {
+	r.byType[reflect.TypeOf(sample)] = fmt
+	return r
+}
+
+// RegisterFunc associates pred with fmt; values for which pred returns true
+// dispatch to fmt. Predicates are tested in registration order, after an
+// exact Register type match misses.
+func (r *FormatterRegistry) RegisterFunc(pred func(v any) bool, fmt Stringer) *FormatterRegistry {
+	r.preds = append(r.preds, predicateFormatter{pred: pred, fmt: fmt})
+	return r
+}
+
+// Fallback overrides the Stringer used when no Register or RegisterFunc entry
+// matches a value. Defaults to Value.
+func (r *FormatterRegistry) Fallback(fmt Stringer) *FormatterRegistry {
+	r.fall = fmt
+	return r
+}
+
+// Dispatch formats v using the Stringer registered for its runtime type, or
+// the first matching RegisterFunc predicate, falling back to the registry's
+// Fallback Stringer (Value by default).
+func (r *FormatterRegistry) Dispatch(v any) string {
+	if fmt, ok := r.byType[reflect.TypeOf(v)]; ok {
+		return fmt(v)
+	}
+	for _, pf := range r.preds {
+		if pf.pred(v) {
+			return pf.fmt(v)
+		}
+	}
+	return r.fall(v)
+}
+
+// Chain composes a sequence of Stringer transforms into a single Stringer.
+// The first Stringer is invoked with the original value; each subsequent one
+// is invoked with the previous step's string result (e.g. array-join then
+// wrap in quotes). An empty Chain behaves like Value.
+func Chain(fmts ...Stringer) Stringer {
+	return func(v any) string {
+		if len(fmts) == 0 {
+			return Value(v)
+		}
+		s := fmts[0](v)
+		for _, next := range fmts[1:] {
+			s = next(s)
+		}
+		return s
+	}
+}
+
+// TimeFormat returns a Stringer that formats time.Time values using layout
+// (see the time package's reference layout). Non-time.Time values fall back
+// to Value.
+func TimeFormat(layout string) Stringer {
+	return func(v any) string {
+		t, ok := v.(time.Time)
+		if !ok {
+			return Value(v)
+		}
+		return t.Format(layout)
+	}
+}
+
+// DurationFormat is a Stringer that formats time.Duration values using
+// time.Duration.String. Non-time.Duration values fall back to Value.
+func DurationFormat(v any) string {
+	d, ok := v.(time.Duration)
+	if !ok {
+		return Value(v)
+	}
+	return d.String()
+}
+
+// BytesFormat returns a Stringer that formats []byte values using enc, either
+// "hex" or "base64". Non-[]byte values, and unrecognized encodings, fall back
+// to Value.
+func BytesFormat(enc string) Stringer {
+	return func(v any) string {
+		b, ok := v.([]byte)
+		if !ok {
+			return Value(v)
+		}
+		switch enc {
+		case "hex":
+			return hex.EncodeToString(b)
+		case "base64":
+			return base64.StdEncoding.EncodeToString(b)
+		default:
+			return Value(v)
+		}
+	}
+}
+
+// NumberFormat returns a Stringer that formats integer, unsigned integer, and
+// floating-point values using verb (a printf verb, e.g. "%d", "%.2f", "%x").
+// Other value types fall back to Value.
+func NumberFormat(verb string) Stringer {
+	return func(v any) string {
+		switch v.(type) {
+		case int, int8, int16, int32, int64,
+			uint, uint8, uint16, uint32, uint64,
+			float32, float64:
+			return fmt.Sprintf(verb, v)
+		default:
+			return Value(v)
+		}
+	}
+}
+
+// MapFormat is a Stringer that formats map[string]any values as
+// comma-separated "k=v" pairs, sorted by key for deterministic output.
+// Non-map[string]any values fall back to Value.
+func MapFormat(v any) string {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return Value(v)
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = k + "=" + Value(m[k])
+	}
+	return strings.Join(parts, ",")
+}
+
+// BoolFlag returns a Stringer that formats a true bool as flag, and a false
+// bool (or any non-bool value) as an empty string, letting a Field be omitted
+// from a Template unless its underlying condition is true.
+func BoolFlag(flag string) Stringer {
+	return func(v any) string {
+		b, ok := v.(bool)
+		if !ok || !b {
+			return ""
+		}
+		return flag
+	}
+}
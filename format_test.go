@@ -0,0 +1,131 @@
+package csf
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_FormatterRegistryDispatch(t *testing.T) {
+	reg := NewFormatterRegistry().
+		Register(0, NumberFormat("%03d")).
+		Register("", Const("str"))
+
+	st := NewTemplate(
+		F("a").Formatter(reg.Dispatch),
+		F("b").Formatter(reg.Dispatch),
+	)
+	s, err := st.String(map[string]any{"a": 7, "b": "ignored"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "007 str" {
+		t.Fatalf("expected '007 str', got %q", s)
+	}
+}
+
+func Test_FormatterRegistryFallback(t *testing.T) {
+	reg := NewFormatterRegistry().Fallback(Const("fallback"))
+	st := NewTemplate(F("a").Formatter(reg.Dispatch))
+	s, err := st.String(map[string]any{"a": 1.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "fallback" {
+		t.Fatalf("expected 'fallback', got %q", s)
+	}
+}
+
+func Test_FormatterRegistryPredicate(t *testing.T) {
+	reg := NewFormatterRegistry().RegisterFunc(func(v any) bool {
+		n, ok := v.(int)
+		return ok && n < 0
+	}, Const("negative"))
+	st := NewTemplate(F("a").Formatter(reg.Dispatch))
+	s, err := st.String(map[string]any{"a": -5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "negative" {
+		t.Fatalf("expected 'negative', got %q", s)
+	}
+}
+
+func Test_TimeFormat(t *testing.T) {
+	st := NewTemplate(F("a").Formatter(TimeFormat("2006-01-02")))
+	s, err := st.String(map[string]any{"a": time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "2024-03-01" {
+		t.Fatalf("expected '2024-03-01', got %q", s)
+	}
+}
+
+func Test_DurationFormat(t *testing.T) {
+	st := NewTemplate(F("a").Formatter(Stringer(DurationFormat)))
+	s, err := st.String(map[string]any{"a": 90 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "1m30s" {
+		t.Fatalf("expected '1m30s', got %q", s)
+	}
+}
+
+func Test_BytesFormat(t *testing.T) {
+	st := NewTemplate(F("a").Formatter(BytesFormat("hex")))
+	s, err := st.String(map[string]any{"a": []byte{0xde, 0xad, 0xbe, 0xef}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "deadbeef" {
+		t.Fatalf("expected 'deadbeef', got %q", s)
+	}
+}
+
+func Test_MapFormat(t *testing.T) {
+	st := NewTemplate(F("a").Formatter(Stringer(MapFormat)))
+	s, err := st.String(map[string]any{"a": map[string]any{"b": 2, "a": 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "a=1,b=2" {
+		t.Fatalf("expected 'a=1,b=2', got %q", s)
+	}
+}
+
+func Test_BoolFlag(t *testing.T) {
+	st := NewTemplate(
+		F("verbose").Formatter(BoolFlag("--verbose")),
+	)
+	s, err := st.String(map[string]any{"verbose": true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "--verbose" {
+		t.Fatalf("expected '--verbose', got %q", s)
+	}
+
+	s, err = st.String(map[string]any{"verbose": false})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "" {
+		t.Fatalf("expected '', got %q", s)
+	}
+}
+
+func Test_Chain(t *testing.T) {
+	st := NewTemplate(
+		F("a").Required().Formatter(Chain(Array(","), func(v any) string {
+			return "[" + v.(string) + "]"
+		})),
+	)
+	s, err := st.String(map[string]any{"a": []string{"x", "y"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "[x,y]" {
+		t.Fatalf("expected '[x,y]', got %q", s)
+	}
+}
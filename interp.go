@@ -0,0 +1,218 @@
+package csf
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LookupFunc resolves a variable name to its string value and whether it was
+// found, or an error if it could not be resolved at all. It is used by
+// Substitute (and Interp) to read variable values from a context map or an
+// alternate source, such as environment variables.
+type LookupFunc func(name string) (string, bool, error)
+
+// mapLookup adapts a context map to a LookupFunc, resolving name as a
+// PathSep-delimited path via resolvePath (e.g. "service.image.tag"). A path
+// is considered found only if it resolves to a non-nil value; the value is
+// formatted using Value.
+func mapLookup(ctx map[string]any) LookupFunc {
+	return func(name string) (string, bool, error) {
+		v, found, err := resolvePath(ctx, name)
+		if err != nil {
+			return "", false, err
+		}
+		if !found {
+			return "", false, nil
+		}
+		return Value(v), true, nil
+	}
+}
+
+// substituteConfig holds the resolved options for a Substitute call.
+type substituteConfig struct {
+	lookup LookupFunc
+}
+
+// SubstituteOption configures the behavior of Substitute and Interp.
+type SubstituteOption func(*substituteConfig)
+
+// WithLookup overrides the default context-map lookup used by Substitute (and
+// Interp), allowing callers to chain additional sources, such as falling back
+// to environment variables when a name is absent from the context map.
+func WithLookup(fn LookupFunc) SubstituteOption {
+	return func(c *substituteConfig) {
+		c.lookup = fn
+	}
+}
+
+// Substitute expands shell/compose-style variable references in s using the
+// provided context map (or an overriding LookupFunc via WithLookup). The
+// supported reference forms are:
+//
+//	${VAR}      - the value of VAR, or an empty string if unset
+//	${VAR:-def} - def if VAR is unset or empty, otherwise the value of VAR
+//	${VAR-def}  - def if VAR is unset, otherwise the value of VAR
+//	${VAR:?msg} - an error containing msg if VAR is unset or empty
+//	$$          - a literal '$'
+//
+// A non-nil error is returned if s contains a malformed reference (e.g. an
+// unterminated "${", an empty variable name, or an unrecognized operator).
+func Substitute(s string, ctx map[string]any, opts ...SubstituteOption) (string, error) {
+	cfg := &substituteConfig{lookup: mapLookup(ctx)}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return substitute(s, cfg.lookup)
+}
+
+// substitute expands s using the given lookup function. See Substitute for
+// the supported reference syntax.
+func substitute(s string, lookup LookupFunc) (string, error) {
+	var sb strings.Builder
+	for i := 0; i < len(s); {
+		if s[i] != '$' {
+			sb.WriteByte(s[i])
+			i++
+			continue
+		}
+		if i+1 < len(s) && s[i+1] == '$' {
+			sb.WriteByte('$')
+			i += 2
+			continue
+		}
+		if i+1 >= len(s) || s[i+1] != '{' {
+			return "", fmt.Errorf("csf: invalid template: stray %q at offset %d", "$", i)
+		}
+		end := strings.IndexByte(s[i+2:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("csf: invalid template: unterminated %q", s[i:])
+		}
+		expr := s[i+2 : i+2+end]
+		val, err := resolveExpr(expr, lookup)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(val)
+		i += 2 + end + 1
+	}
+	return sb.String(), nil
+}
+
+// resolveExpr evaluates the contents of a single "${...}" reference (with the
+// braces stripped) against lookup.
+func resolveExpr(expr string, lookup LookupFunc) (string, error) {
+	name, op, rest := splitExpr(expr)
+	if name == "" {
+		return "", fmt.Errorf("csf: invalid template: empty variable name in %q", "${"+expr+"}")
+	}
+	val, found, err := lookup(name)
+	if err != nil {
+		return "", err
+	}
+	switch op {
+	case "":
+		if !found {
+			return "", nil
+		}
+		return val, nil
+	case ":-":
+		if !found || val == "" {
+			return rest, nil
+		}
+		return val, nil
+	case "-":
+		if !found {
+			return rest, nil
+		}
+		return val, nil
+	case ":?":
+		if !found || val == "" {
+			msg := rest
+			if msg == "" {
+				msg = fmt.Sprintf("required variable %q is unset", name)
+			}
+			return "", fmt.Errorf("csf: %s", msg)
+		}
+		return val, nil
+	default:
+		return "", fmt.Errorf("csf: invalid template: unknown operator %q in %q", op, "${"+expr+"}")
+	}
+}
+
+// splitExpr splits the contents of a "${...}" reference into its variable
+// name, operator (one of "", "-", ":-", ":?"), and the remainder following
+// the operator (a default value or error message). If the text after the name
+// starts with none of these operators, that whole text is returned as op with
+// an empty rest.
+func splitExpr(expr string) (name, op, rest string) {
+	i := nameLen(expr)
+	name = expr[:i]
+	tail := expr[i:]
+	switch {
+	case tail == "":
+		return name, "", ""
+	case strings.HasPrefix(tail, ":-"):
+		return name, ":-", tail[2:]
+	case strings.HasPrefix(tail, ":?"):
+		return name, ":?", tail[2:]
+	case strings.HasPrefix(tail, "-"):
+		return name, "-", tail[1:]
+	default:
+		return name, tail, ""
+	}
+}
+
+// isNameByte reports whether b may appear in a variable name on its own
+// (i.e. excluding PathSep, which nameLen handles separately since it may be
+// more than one byte long).
+func isNameByte(b byte) bool {
+	return b == '_' ||
+		('a' <= b && b <= 'z') ||
+		('A' <= b && b <= 'Z') ||
+		('0' <= b && b <= '9')
+}
+
+// nameLen returns the length of the leading variable-name portion of expr,
+// treating a PathSep occurrence of any length as part of the name so dotted
+// paths like "service.image.tag" resolve the same way inside ${...}
+// interpolation patterns as they do via Field/resolvePath.
+func nameLen(expr string) int {
+	i := 0
+	for i < len(expr) {
+		if isNameByte(expr[i]) {
+			i++
+			continue
+		}
+		if PathSep != "" && strings.HasPrefix(expr[i:], PathSep) {
+			i += len(PathSep)
+			continue
+		}
+		break
+	}
+	return i
+}
+
+// InterpField is an Eval implementation that expands a shell/compose-style
+// interpolation pattern (see Substitute) against the template's context map.
+type InterpField struct {
+	pattern string
+	opts    []SubstituteOption
+}
+
+// String expands the InterpField's pattern using Substitute, returning any
+// error produced by a malformed pattern or an unmet "${VAR:?msg}" reference.
+func (f *InterpField) String(c map[string]any) (string, error) {
+	return Substitute(f.pattern, c, f.opts...)
+}
+
+// Interp creates a new Eval that expands shell/compose-style variable
+// references (${VAR}, ${VAR:-default}, ${VAR-default}, ${VAR:?err}, and $$)
+// in pattern against the context map. See Substitute for the supported
+// reference syntax and SubstituteOption for overriding how variables are
+// resolved.
+func Interp(pattern string, opts ...SubstituteOption) *InterpField {
+	return &InterpField{
+		pattern: pattern,
+		opts:    opts,
+	}
+}
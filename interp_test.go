@@ -0,0 +1,110 @@
+package csf
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_SubstitutePlain(t *testing.T) {
+	s, err := Substitute("hello ${name}!", map[string]any{"name": "world"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "hello world!" {
+		t.Fatalf("expected 'hello world!', got %q", s)
+	}
+}
+
+func Test_SubstituteUnsetPlain(t *testing.T) {
+	s, err := Substitute("x=${missing}", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "x=" {
+		t.Fatalf("expected 'x=', got %q", s)
+	}
+}
+
+func Test_SubstituteDefaultOnEmpty(t *testing.T) {
+	s, err := Substitute("${v:-fallback}", map[string]any{"v": ""})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "fallback" {
+		t.Fatalf("expected 'fallback', got %q", s)
+	}
+}
+
+func Test_SubstituteDefaultOnUnsetOnly(t *testing.T) {
+	s, err := Substitute("${v-fallback}", map[string]any{"v": ""})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "" {
+		t.Fatalf("expected '', got %q", s)
+	}
+
+	s, err = Substitute("${v-fallback}", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "fallback" {
+		t.Fatalf("expected 'fallback', got %q", s)
+	}
+}
+
+func Test_SubstituteRequired(t *testing.T) {
+	_, err := Substitute("${v:?v is required}", nil)
+	if err == nil {
+		t.Fatal("expected error for missing required variable")
+	}
+	if !strings.Contains(err.Error(), "v is required") {
+		t.Fatalf("expected error to contain message, got %q", err)
+	}
+}
+
+func Test_SubstituteEscape(t *testing.T) {
+	s, err := Substitute("cost: $$5", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "cost: $5" {
+		t.Fatalf("expected 'cost: $5', got %q", s)
+	}
+}
+
+func Test_SubstituteMalformed(t *testing.T) {
+	cases := []string{"${", "${ foo}", "${foo!}"}
+	for _, c := range cases {
+		if _, err := Substitute(c, nil); err == nil {
+			t.Errorf("expected error for pattern %q, got nil", c)
+		}
+	}
+}
+
+func Test_SubstituteWithLookup(t *testing.T) {
+	env := map[string]string{"HOME": "/root"}
+	s, err := Substitute("${HOME}", nil, WithLookup(func(name string) (string, bool, error) {
+		v, ok := env[name]
+		return v, ok, nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "/root" {
+		t.Fatalf("expected '/root', got %q", s)
+	}
+}
+
+func Test_InterpInTemplate(t *testing.T) {
+	st := NewTemplate(
+		Interp("${greeting:-hi}, ${name}"),
+	)
+	s, err := st.String(map[string]any{"name": "world"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "hi, world" {
+		t.Fatalf("expected 'hi, world', got %q", s)
+	}
+}
@@ -0,0 +1,50 @@
+package csf
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PathSep separates path segments when a Field's id (or a Substitute
+// variable name) is resolved as a dotted path into nested maps and slices,
+// e.g. "service.image.tag". Override this package variable if your context
+// map's keys legitimately contain the default separator.
+var PathSep = "."
+
+// resolvePath resolves a PathSep-delimited path against ctx, descending
+// through nested map[string]any values and, for numeric segments, []any
+// slices (e.g. "args.0" indexes the first element of ctx["args"]).
+//
+// found is false (with a nil error) if the path is simply absent: a missing
+// map key, a nil intermediate value, or an out-of-range/non-numeric slice
+// index. A non-nil error is returned only when an intermediate segment
+// resolves to a value that is neither a map[string]any nor a []any (other
+// map and slice types included), so the remaining path segments cannot be
+// resolved at all.
+func resolvePath(ctx map[string]any, id string) (v any, found bool, err error) {
+	segs := strings.Split(id, PathSep)
+	var cur any = ctx
+	for i, seg := range segs {
+		switch c := cur.(type) {
+		case map[string]any:
+			next, ok := c[seg]
+			if !ok || next == nil {
+				return nil, false, nil
+			}
+			cur = next
+		case []any:
+			idx, err := strconv.Atoi(seg)
+			if err != nil || idx < 0 || idx >= len(c) {
+				return nil, false, nil
+			}
+			if c[idx] == nil {
+				return nil, false, nil
+			}
+			cur = c[idx]
+		default:
+			return nil, false, fmt.Errorf("csf: expected map at %q, got %T", strings.Join(segs[:i], PathSep), cur)
+		}
+	}
+	return cur, true, nil
+}
@@ -0,0 +1,125 @@
+package csf
+
+import "testing"
+
+func Test_DottedFieldLookup(t *testing.T) {
+	st := NewTemplate(
+		F("service.image.tag").Required(),
+	)
+	s, err := st.String(map[string]any{
+		"service": map[string]any{
+			"image": map[string]any{
+				"tag": "v1.2.3",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "v1.2.3" {
+		t.Fatalf("expected 'v1.2.3', got %q", s)
+	}
+}
+
+func Test_DottedFieldMissingIsEmpty(t *testing.T) {
+	st := NewTemplate(
+		F("service.image.tag"),
+	)
+	s, err := st.String(map[string]any{
+		"service": map[string]any{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "" {
+		t.Fatalf("expected empty string, got %q", s)
+	}
+}
+
+func Test_DottedFieldTypeMismatch(t *testing.T) {
+	st := NewTemplate(
+		F("service.image.tag"),
+	)
+	_, err := st.String(map[string]any{
+		"service": map[string]any{
+			"image": "not-a-map",
+		},
+	})
+	if err == nil {
+		t.Fatal("expected error for intermediate type mismatch")
+	}
+}
+
+func Test_DottedFieldSliceIndex(t *testing.T) {
+	st := NewTemplate(
+		F("args.0").Required(),
+	)
+	s, err := st.String(map[string]any{
+		"args": []any{"first", "second"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "first" {
+		t.Fatalf("expected 'first', got %q", s)
+	}
+}
+
+func Test_CustomPathSep(t *testing.T) {
+	old := PathSep
+	PathSep = "/"
+	defer func() { PathSep = old }()
+
+	st := NewTemplate(
+		F("service/image/tag").Required(),
+	)
+	s, err := st.String(map[string]any{
+		"service": map[string]any{
+			"image": map[string]any{
+				"tag": "v2",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "v2" {
+		t.Fatalf("expected 'v2', got %q", s)
+	}
+}
+
+func Test_SubstituteMultiCharPathSep(t *testing.T) {
+	old := PathSep
+	PathSep = "::"
+	defer func() { PathSep = old }()
+
+	s, err := Substitute("${service::image::tag}", map[string]any{
+		"service": map[string]any{
+			"image": map[string]any{
+				"tag": "v3",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "v3" {
+		t.Fatalf("expected 'v3', got %q", s)
+	}
+}
+
+func Test_SubstituteDottedPath(t *testing.T) {
+	s, err := Substitute("${service.image.tag}", map[string]any{
+		"service": map[string]any{
+			"image": map[string]any{
+				"tag": "v1",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "v1" {
+		t.Fatalf("expected 'v1', got %q", s)
+	}
+}
@@ -0,0 +1,130 @@
+package csf
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Format selects how a Template's Render method encodes resolved field
+// values.
+type Format int
+
+const (
+	// FormatPlain joins resolved field values with the template's separator,
+	// identical to Template.String.
+	FormatPlain Format = iota
+	// FormatJSON emits a JSON object keyed by each Named field's ID, mapped
+	// to its resolved string value. Fields that do not implement Named, that
+	// have an empty ID, or that resolve to an empty string, are omitted.
+	FormatJSON
+	// FormatKV emits "key=value" pairs joined by the template's separator,
+	// shell-quoting values that are empty or contain whitespace, quotes or
+	// other shell metacharacters.
+	// Fields that do not implement Named, or that have an empty ID, are
+	// omitted.
+	FormatKV
+)
+
+// TemplateOptions configures a Template's join separator and rendering mode.
+type TemplateOptions struct {
+	// Separator joins resolved field values in FormatPlain and FormatKV
+	// output. Defaults to " " if empty and NoSeparator is false.
+	Separator string
+	// NoSeparator requests genuine no-separator concatenation ("") rather
+	// than the default " ". This is distinct from leaving Separator empty,
+	// since TemplateOptions{} (the zero value) means "use the default space
+	// separator."
+	NoSeparator bool
+	// SkipEmpty omits fields that resolve to an empty string from FormatKV
+	// output. It has no effect on FormatPlain (which always skips empty
+	// values) or FormatJSON (which always skips empty values).
+	SkipEmpty bool
+	// Format selects the rendering mode used by Render. Defaults to
+	// FormatPlain.
+	Format Format
+}
+
+// NewTemplateWithOptions creates a new Template instance with the provided
+// options and list of Eval values. See NewTemplate for the default behavior
+// when no options are required.
+func NewTemplateWithOptions(opts TemplateOptions, fields ...Eval) *Template {
+	return &Template{
+		fields: fields,
+		opts:   opts,
+	}
+}
+
+// Render generates the template's output in its configured Format, returning
+// the result as a byte slice. FormatPlain renders identically to
+// Template.String; FormatJSON and FormatKV additionally require fields to
+// implement Named in order to be included (see F, and As on Constant and
+// FirstMatch).
+func (t *Template) Render(ctx map[string]any) ([]byte, error) {
+	switch t.opts.Format {
+	case FormatJSON:
+		return t.renderJSON(ctx)
+	case FormatKV:
+		return t.renderKV(ctx)
+	default:
+		s, err := t.String(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(s), nil
+	}
+}
+
+// renderJSON evaluates each Named field and marshals the results as a JSON
+// object keyed by field ID, omitting empty values.
+func (t *Template) renderJSON(ctx map[string]any) ([]byte, error) {
+	out := make(map[string]string, len(t.fields))
+	for _, f := range t.fields {
+		named, ok := f.(Named)
+		if !ok || named.ID() == "" {
+			continue
+		}
+		s, err := f.String(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error evaluating field %q: %w", named.ID(), err)
+		}
+		if s == "" {
+			continue
+		}
+		out[named.ID()] = s
+	}
+	return json.Marshal(out)
+}
+
+// renderKV evaluates each Named field and joins "key=value" pairs using the
+// template's separator, shell-quoting values as needed.
+func (t *Template) renderKV(ctx map[string]any) ([]byte, error) {
+	parts := make([]string, 0, len(t.fields))
+	for _, f := range t.fields {
+		named, ok := f.(Named)
+		if !ok || named.ID() == "" {
+			continue
+		}
+		s, err := f.String(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error evaluating field %q: %w", named.ID(), err)
+		}
+		if s == "" && t.opts.SkipEmpty {
+			continue
+		}
+		parts = append(parts, named.ID()+"="+quoteKV(s))
+	}
+	return []byte(strings.Join(parts, t.sep())), nil
+}
+
+// quoteKV POSIX single-quotes s if it is empty or contains whitespace or a
+// character that would otherwise need shell escaping, so the "key=value"
+// output is safe to source/eval in a real shell. An embedded single quote is
+// escaped by closing the quote, emitting a backslash-escaped quote, then
+// reopening the quote.
+func quoteKV(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t\r\n\"'$`\\;&|<>()*?[]{}#~!") {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,120 @@
+package csf
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func Test_TemplateCustomSeparator(t *testing.T) {
+	st := NewTemplateWithOptions(
+		TemplateOptions{Separator: ", "},
+		F("a"),
+		F("b"),
+	)
+	s, err := st.String(map[string]any{"a": "foo", "b": "bar"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "foo, bar" {
+		t.Fatalf("expected 'foo, bar', got %q", s)
+	}
+}
+
+func Test_RenderPlain(t *testing.T) {
+	st := NewTemplateWithOptions(
+		TemplateOptions{},
+		F("a"),
+		F("b"),
+	)
+	b, err := st.Render(map[string]any{"a": "foo", "b": "bar"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "foo bar" {
+		t.Fatalf("expected 'foo bar', got %q", b)
+	}
+}
+
+func Test_RenderJSON(t *testing.T) {
+	st := NewTemplateWithOptions(
+		TemplateOptions{Format: FormatJSON},
+		F("a"),
+		F("b"),
+		C("unnamed"),
+	)
+	b, err := st.Render(map[string]any{"a": "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out map[string]string
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != 1 || out["a"] != "foo" {
+		t.Fatalf("expected {\"a\":\"foo\"}, got %q", b)
+	}
+}
+
+func Test_RenderKV(t *testing.T) {
+	st := NewTemplateWithOptions(
+		TemplateOptions{Format: FormatKV},
+		F("a"),
+		F("b"),
+	)
+	b, err := st.Render(map[string]any{"a": "foo bar", "b": "baz"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `a='foo bar' b=baz` {
+		t.Fatalf("expected \"a='foo bar' b=baz\", got %q", b)
+	}
+}
+
+func Test_RenderKVSkipEmpty(t *testing.T) {
+	st := NewTemplateWithOptions(
+		TemplateOptions{Format: FormatKV, SkipEmpty: true},
+		F("a"),
+		F("b"),
+	)
+	b, err := st.Render(map[string]any{"a": "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "a=foo" {
+		t.Fatalf("expected 'a=foo', got %q", b)
+	}
+}
+
+func Test_RenderKVEscapesShellMetacharacters(t *testing.T) {
+	st := NewTemplateWithOptions(
+		TemplateOptions{Format: FormatKV},
+		F("a"),
+	)
+	raw := "$HOME `whoami` and 'quote'"
+	b, err := st.Render(map[string]any{"a": raw})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "a='" + strings.ReplaceAll(raw, "'", `'\''`) + "'"
+	if string(b) != want {
+		t.Fatalf("expected %q, got %q", want, b)
+	}
+}
+
+func Test_NamedFirstMatchAndConstant(t *testing.T) {
+	fm := First(F("a"), F("b")).As("choice")
+	c := C("fixed").As("label")
+	st := NewTemplateWithOptions(
+		TemplateOptions{Format: FormatKV},
+		fm,
+		c,
+	)
+	b, err := st.Render(map[string]any{"b": "bar"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "choice=bar label=fixed" {
+		t.Fatalf("expected 'choice=bar label=fixed', got %q", b)
+	}
+}